@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"math/bits"
+	"math/rand"
+
+	"github.com/apache/arrow/go/v9/arrow/internal/flatbuf"
+)
+
+// cdcWindow is the size, in bytes, of the rolling hash window used to find
+// content-defined chunk boundaries.
+const cdcWindow = 48
+
+// cdcParams configures content-defined chunking of a single Binary/String
+// values buffer before compression. Chunk boundaries are a pre-compression
+// detail only: they never change the buffer's bytes or the IPC metadata, so
+// any reader decompresses the result exactly as before.
+type cdcParams struct {
+	minChunk, avgChunk, maxChunk int
+}
+
+// WithContentDefinedChunking enables a rolling-hash rechunking pass over
+// Binary/String (and Large variants) value buffers before they are
+// compressed with ZSTD or LZ4. Instead of handing the codec the whole values
+// buffer in one Write, chunk boundaries are picked at content-aligned,
+// rolling-hash-determined offsets between minChunk and maxChunk bytes (with
+// avgChunk as the target size), and each chunk is written (and, where the
+// codec supports it, flushed) separately.
+//
+// This does not change what is written to the wire; it only changes where
+// the compressor's internal state resets, so that repeated spans of bytes
+// across different batches (e.g. a recurring JSON field in a log column)
+// compress to identical byte sequences instead of being perturbed by
+// whatever preceded them in the buffer.
+func WithContentDefinedChunking(minChunk, avgChunk, maxChunk int) Option {
+	return func(cfg *config) {
+		cfg.cdc = &cdcParams{minChunk: minChunk, avgChunk: avgChunk, maxChunk: maxChunk}
+	}
+}
+
+func (p *cdcParams) normalized() (min, avg, max int) {
+	min, avg, max = p.minChunk, p.avgChunk, p.maxChunk
+	if avg <= 0 {
+		avg = 8 << 10
+	}
+	if min <= 0 {
+		min = avg / 4
+	}
+	if max <= 0 {
+		max = avg * 4
+	}
+	return
+}
+
+// isChunkableCodec reports whether codec's internal state can meaningfully
+// be reset mid-stream, i.e. whether content-defined chunking is worth doing
+// at all for it.
+func isChunkableCodec(codec flatbuf.CompressionType) bool {
+	return codec == flatbuf.CompressionTypeZSTD || codec == flatbuf.CompressionTypeLZ4_FRAME
+}
+
+// flusher is implemented by codecs that can flush and reset their internal
+// state mid-stream (e.g. a ZSTD frame boundary). Codecs that don't
+// implement it still compress chunked input correctly; they just don't get
+// the improved cross-batch reuse content-defined chunking is meant to buy.
+type flusher interface {
+	Flush() error
+}
+
+// writeChunked rechunks data at content-defined boundaries and writes each
+// chunk to codec in turn, flushing between chunks when codec supports it.
+func writeChunked(codec compressor, data []byte, params *cdcParams) error {
+	f, canFlush := codec.(flusher)
+	for _, chunk := range cdcCutPoints(data, params) {
+		if _, err := codec.Write(chunk); err != nil {
+			return err
+		}
+		if canFlush {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// buzhashTable is a fixed (not randomized per-process) table so that the
+// same content always produces the same cut points, which is the entire
+// point of content-defined chunking: identical spans in different record
+// batches must hash, and therefore chunk, identically.
+var buzhashTable = func() (t [256]uint64) {
+	r := rand.New(rand.NewSource(0x41727277)) // "Arrw", fixed seed
+	for i := range t {
+		t[i] = r.Uint64()
+	}
+	return t
+}()
+
+// cdcCutPoints splits data into chunks using a buzhash-style rolling hash
+// over a cdcWindow-byte window: a cut point falls wherever the low bits of
+// the hash selected by mask are all zero, subject to the min/max chunk
+// clamps in params.
+func cdcCutPoints(data []byte, params *cdcParams) [][]byte {
+	minChunk, avgChunk, maxChunk := params.normalized()
+
+	maskBits := bits.Len(uint(avgChunk - 1))
+	if maskBits < 1 {
+		maskBits = 1
+	}
+	mask := uint64(1)<<uint(maskBits) - 1
+
+	var (
+		chunks  [][]byte
+		start   int
+		hash    uint64
+		window  [cdcWindow]byte
+		winPos  int
+		winFull bool
+	)
+
+	for i, b := range data {
+		// only once the window already holds cdcWindow bytes does b displace
+		// a byte that is part of the hash.
+		evict := winFull
+		outgoing := window[winPos]
+		window[winPos] = b
+		winPos++
+		if winPos == cdcWindow {
+			winPos = 0
+			winFull = true
+		}
+
+		hash = bits.RotateLeft64(hash, 1) ^ buzhashTable[b]
+		if evict {
+			hash ^= bits.RotateLeft64(buzhashTable[outgoing], cdcWindow%64)
+		}
+
+		n := i - start + 1
+		switch {
+		case n < minChunk:
+			continue
+		case n >= maxChunk || hash&mask == 0:
+			chunks = append(chunks, data[start:i+1])
+			start = i + 1
+			hash, winPos, winFull = 0, 0, false
+			window = [cdcWindow]byte{}
+		}
+	}
+	if start < len(data) {
+		chunks = append(chunks, data[start:])
+	}
+	return chunks
+}
@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCDCCutPointsDeterministic(t *testing.T) {
+	data := make([]byte, 256<<10)
+	rand.New(rand.NewSource(1)).Read(data)
+	params := &cdcParams{minChunk: 1 << 10, avgChunk: 8 << 10, maxChunk: 32 << 10}
+
+	first := cdcCutPoints(data, params)
+	second := cdcCutPoints(data, params)
+
+	if len(first) != len(second) {
+		t.Fatalf("cut points are not deterministic: got %d chunks then %d chunks for the same input", len(first), len(second))
+	}
+	for i := range first {
+		if string(first[i]) != string(second[i]) {
+			t.Fatalf("chunk %d differs between identical runs", i)
+		}
+	}
+}
+
+func TestCDCCutPointsMatchAvgChunkSize(t *testing.T) {
+	const avgChunk = 8 << 10
+	data := make([]byte, 4<<20)
+	rand.New(rand.NewSource(2)).Read(data)
+	params := &cdcParams{minChunk: avgChunk / 4, avgChunk: avgChunk, maxChunk: avgChunk * 4}
+
+	chunks := cdcCutPoints(data, params)
+	if len(chunks) == 0 {
+		t.Fatal("expected at least one chunk")
+	}
+
+	var total int
+	for _, c := range chunks {
+		total += len(c)
+	}
+	mean := float64(total) / float64(len(chunks))
+
+	// With the mask sized to log2(avgChunk) bits, the mean chunk length
+	// should land within a factor of 2 of avgChunk. Before the mask-size
+	// fix this mean was close to 2*avgChunk instead.
+	if mean < avgChunk/2 || mean > avgChunk*2 {
+		t.Fatalf("mean chunk length %.0f is not within 2x of avgChunk %d", mean, avgChunk)
+	}
+}
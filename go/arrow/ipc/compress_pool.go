@@ -0,0 +1,258 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+)
+
+// compressJob is one body buffer to compress, tagged with the codec instance
+// pool it should draw from. done is invoked exactly once with the resulting
+// (already-framed) buffer, or a non-nil error.
+type compressJob struct {
+	codec flatbuf.CompressionType
+	// level, when > 0, selects a non-default ZSTD level for this buffer
+	// (e.g. a dictionary's values buffer); see WithDictionaryCompressionLevel.
+	level int
+	buf   *memory.Buffer
+	done  func(*memory.Buffer, error)
+
+	// chunked and cdc, when set, request that buf be rechunked at
+	// content-defined boundaries and fed to the codec chunk by chunk rather
+	// than in a single Write; see WithContentDefinedChunking.
+	chunked bool
+	cdc     *cdcParams
+}
+
+// codecKey identifies a pooled compressor by both its wire codec and the
+// level it was constructed at, since a dictionary's buffers may be
+// compressed at a higher level than everything else even when they share a
+// codec type with the rest of the stream.
+type codecKey struct {
+	codec flatbuf.CompressionType
+	level int
+}
+
+// compressorPool owns a fixed set of long-lived compression workers shared
+// by every Write call on a Writer, instead of spinning up fresh goroutines
+// and codec/buffer allocations per call. Workers pull jobs off a bounded
+// channel, so a burst of wide batches backs up onto the submitter rather
+// than growing memory unbounded.
+type compressorPool struct {
+	jobs chan compressJob
+	wg   sync.WaitGroup
+
+	mu         sync.Mutex
+	bufs       sync.Pool // *bytes.Buffer
+	codecPools map[codecKey]*sync.Pool
+}
+
+// newCompressorPool starts n compression workers. The workers run until
+// close is called.
+func newCompressorPool(n int) *compressorPool {
+	cp := &compressorPool{
+		jobs:       make(chan compressJob, n),
+		codecPools: make(map[codecKey]*sync.Pool),
+	}
+	cp.bufs.New = func() interface{} { return new(bytes.Buffer) }
+
+	cp.wg.Add(n)
+	for i := 0; i < n; i++ {
+		go cp.work()
+	}
+	return cp
+}
+
+func (cp *compressorPool) codecPool(key codecKey) *sync.Pool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	p, ok := cp.codecPools[key]
+	if !ok {
+		p = &sync.Pool{New: func() interface{} {
+			if key.level > 0 && key.codec == flatbuf.CompressionTypeZSTD {
+				if codec, err := newLeveledZSTDCompressor(key.level); err == nil {
+					return codec
+				}
+				// fall through to the default-level codec if construction
+				// at key.level somehow fails.
+			}
+			return getCompressor(key.codec)
+		}}
+		cp.codecPools[key] = p
+	}
+	return p
+}
+
+func (cp *compressorPool) work() {
+	defer cp.wg.Done()
+	for job := range cp.jobs {
+		buf, err := cp.run(job)
+		job.done(buf, err)
+	}
+}
+
+func (cp *compressorPool) run(job compressJob) (*memory.Buffer, error) {
+	in := job.buf
+	pool := cp.codecPool(codecKey{codec: job.codec, level: job.level})
+	codec := pool.Get().(compressor)
+	defer pool.Put(codec)
+
+	buf := cp.bufs.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer cp.bufs.Put(buf)
+
+	buf.Grow(codec.MaxCompressedLen(in.Len()) + arrow.Int64SizeBytes)
+	if err := binary.Write(buf, binary.LittleEndian, uint64(in.Len())); err != nil {
+		return nil, err
+	}
+	codec.Reset(buf)
+	if job.chunked && job.cdc != nil {
+		if err := writeChunked(codec, in.Bytes(), job.cdc); err != nil {
+			return nil, err
+		}
+	} else if _, err := codec.Write(in.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := codec.Close(); err != nil {
+		return nil, err
+	}
+
+	// buf is going back into the pool, so the result must be an independent
+	// copy rather than a view over its backing array.
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return memory.NewBufferBytes(out), nil
+}
+
+// compressPayload compresses every non-empty buffer of p in place, blocking
+// until all of them are done. Used for the (small, synchronous) dictionary
+// batch path. codecs gives the codec to use for each buffer index (see
+// recordEncoder.resolveBufferCodecs); level, when > 0, requests a non-default
+// ZSTD level for every buffer (used for a dictionary's values at
+// WithDictionaryCompressionLevel). cdcEligible marks which buffer indices
+// are Binary/String value buffers eligible for content-defined chunking, if
+// cdc is non-nil and the buffer's codec supports it.
+func (cp *compressorPool) compressPayload(p *Payload, codecs []flatbuf.CompressionType, level int, cdcEligible map[int]bool, cdc *cdcParams) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for idx := range p.body {
+		if p.body[idx] == nil || p.body[idx].Len() == 0 || codecs[idx] == -1 {
+			// codecs[idx] == -1 means fieldCodec chose to leave this buffer
+			// uncompressed.
+			continue
+		}
+		wg.Add(1)
+		idx := idx
+		codec := codecs[idx]
+		cp.jobs <- compressJob{
+			codec:   codec,
+			level:   level,
+			buf:     p.body[idx],
+			chunked: cdc != nil && isChunkableCodec(codec) && cdcEligible[idx],
+			cdc:     cdc,
+			done: func(buf *memory.Buffer, err error) {
+				defer wg.Done()
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+					return
+				}
+				p.body[idx] = buf
+			},
+		}
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// compressPayloadAsync submits every non-empty buffer of p for compression
+// and returns immediately; onComplete runs exactly once, from whichever
+// worker goroutine finishes last, once every buffer of p has either been
+// replaced by its compressed form or the first error has been recorded. If p
+// has no buffer to compress, onComplete(nil) runs on the caller's goroutine
+// before compressPayloadAsync returns.
+// Buffers are written back into p.body at their original index, so no
+// explicit reordering step is needed: each payload's buffers only ever
+// compete with themselves, never with another payload's.
+func (cp *compressorPool) compressPayloadAsync(p *Payload, codecs []flatbuf.CompressionType, level int, cdcEligible map[int]bool, cdc *cdcParams, onComplete func(error)) {
+	pending := 0
+	for idx, buf := range p.body {
+		if buf != nil && buf.Len() > 0 && codecs[idx] != -1 {
+			pending++
+		}
+	}
+	if pending == 0 {
+		onComplete(nil)
+		return
+	}
+
+	var (
+		mu        sync.Mutex
+		remaining = pending
+		firstErr  error
+	)
+
+	for idx := range p.body {
+		if p.body[idx] == nil || p.body[idx].Len() == 0 || codecs[idx] == -1 {
+			continue
+		}
+		idx := idx
+		codec := codecs[idx]
+		cp.jobs <- compressJob{
+			codec:   codec,
+			level:   level,
+			buf:     p.body[idx],
+			chunked: cdc != nil && isChunkableCodec(codec) && cdcEligible[idx],
+			cdc:     cdc,
+			done: func(buf *memory.Buffer, err error) {
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					p.body[idx] = buf
+				}
+				remaining--
+				done := remaining == 0
+				result := firstErr
+				mu.Unlock()
+
+				if done {
+					onComplete(result)
+				}
+			},
+		}
+	}
+}
+
+// close stops all workers, waiting for in-flight jobs to finish.
+func (cp *compressorPool) close() {
+	close(cp.jobs)
+	cp.wg.Wait()
+}
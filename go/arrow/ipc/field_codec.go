@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/internal/flatbuf"
+	"github.com/klauspost/compress/zstd"
+)
+
+// WithFieldCodec configures a per-field compression codec: fn is called for
+// every column (and, for a struct, every nested field) with its dotted path
+// from the record root and its data type, and picks the flatbuf.CompressionType
+// to compress that field's buffers with. This lets a caller, for instance,
+// ZSTD-compress a wide string column, LZ4 a numeric one, and leave a small
+// bitmap uncompressed.
+//
+// fn is consulted even when the Writer has no base codec configured (i.e.
+// WithCodec was never passed), in which case a batch whose buffers fn maps
+// uniformly to one codec is compressed with it (or left uncompressed, for
+// -1), subject to the single-codec rule below.
+//
+// The IPC format only records a single codec per record batch, so fn's
+// choices are only honored when they turn out identical across every buffer
+// of a given batch; otherwise the batch falls back to the Writer's own codec
+// (set via WithCodec, or uncompressed if it wasn't) for every buffer. See
+// WithStrictFieldCodec to turn that fallback into an error instead.
+func WithFieldCodec(fn func(path []string, dt arrow.DataType) flatbuf.CompressionType) Option {
+	return func(cfg *config) {
+		cfg.fieldCodec = fn
+	}
+}
+
+// WithStrictFieldCodec controls what happens when WithFieldCodec picks
+// different codecs across the fields of a single record batch, which the IPC
+// format cannot represent. By default (strict=false) the batch silently
+// falls back to the Writer's own codec for every buffer. With strict=true,
+// Write instead returns an error, for callers whose downstream readers
+// require every batch to use the codec its fields were configured for.
+func WithStrictFieldCodec(strict bool) Option {
+	return func(cfg *config) {
+		cfg.strictFieldCodec = strict
+	}
+}
+
+// WithDictionaryCompressionLevel sets the ZSTD level used to compress
+// dictionary batches, in place of whatever default level getCompressor would
+// otherwise use. Dictionaries are written once and then reused by every
+// record batch that references them, so spending extra CPU for a better
+// ratio on the (typically much smaller) dictionary payload is often a good
+// trade. It only affects dictionary buffers compressed with ZSTD, whether
+// that codec comes from WithCodec or from WithFieldCodec. level is on the
+// reference zstd scale (e.g. 19) and is mapped to the nearest level the
+// encoder supports.
+func WithDictionaryCompressionLevel(level int) Option {
+	return func(cfg *config) {
+		cfg.dictCodecLevel = level
+	}
+}
+
+// leveledZSTDCompressor is a ZSTD compressor built at a non-default level,
+// for WithDictionaryCompressionLevel. getCompressor's codecs are always
+// built at their default level, so obtaining a different one means
+// constructing the encoder at that level up front rather than reconfiguring
+// an existing instance.
+type leveledZSTDCompressor struct {
+	*zstd.Encoder
+}
+
+func (leveledZSTDCompressor) MaxCompressedLen(n int) int {
+	// At least zstd's own worst-case bound: the input size, plus 1/256 of it,
+	// plus a fixed 512-byte allowance for frame/block headers.
+	return n + (n >> 8) + 512
+}
+
+// newLeveledZSTDCompressor returns a ZSTD compressor constructed at level,
+// for compressing a dictionary's buffers at a non-default level instead of
+// whatever level getCompressor(flatbuf.CompressionTypeZSTD) would use.
+func newLeveledZSTDCompressor(level int) (compressor, error) {
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	if err != nil {
+		return nil, err
+	}
+	return leveledZSTDCompressor{enc}, nil
+}
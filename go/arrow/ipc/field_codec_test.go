@@ -0,0 +1,159 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/array"
+	"github.com/apache/arrow/go/v9/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+)
+
+func highlyCompressibleRecord(mem memory.Allocator, schema *arrow.Schema, value string) arrow.Record {
+	bldr := array.NewStringBuilder(mem)
+	defer bldr.Release()
+	bldr.Append(value)
+	arr := bldr.NewStringArray()
+	defer arr.Release()
+	return array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+}
+
+// readBackString writes rec through a Writer configured with opts, reads it
+// back with NewReader, and returns the decoded string column's sole value,
+// failing the test if the record can't be decoded at all (as would happen if
+// a reader silently failed to decompress a field-codec or dict-level batch).
+func readBackString(t *testing.T, mem memory.Allocator, schema *arrow.Schema, rec arrow.Record, opts ...Option) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, append([]Option{WithSchema(schema), WithAllocator(mem)}, opts...)...)
+	if err := w.Write(rec); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(&buf, WithSchema(schema), WithAllocator(mem))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if !r.Next() {
+		t.Fatalf("expected one record batch, got none (err: %v)", r.Err())
+	}
+
+	col, ok := r.Record().Column(0).(*array.String)
+	if !ok {
+		t.Fatalf("expected column 0 to decode as a string array, got %T", r.Record().Column(0))
+	}
+	if col.Len() != 1 {
+		t.Fatalf("got %d values, want 1", col.Len())
+	}
+	return col.Value(0)
+}
+
+// TestWithFieldCodecWithoutBaseCodec guards against WithFieldCodec being a
+// silent no-op when the Writer has no base codec (WithCodec was never
+// passed): a uniform field codec should still compress every batch, and the
+// compressed stream must still decode back to the original value.
+func TestWithFieldCodecWithoutBaseCodec(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f0", Type: arrow.BinaryTypes.String}}, nil)
+	value := strings.Repeat("arrow-ipc-field-codec", 4096)
+
+	rec := highlyCompressibleRecord(mem, schema, value)
+	defer rec.Release()
+	var uncompressed bytes.Buffer
+	wUncompressed := NewWriter(&uncompressed, WithSchema(schema), WithAllocator(mem))
+	if err := wUncompressed.Write(rec); err != nil {
+		t.Fatalf("Write (uncompressed): %v", err)
+	}
+	if err := wUncompressed.Close(); err != nil {
+		t.Fatalf("Close (uncompressed): %v", err)
+	}
+
+	rec2 := highlyCompressibleRecord(mem, schema, value)
+	defer rec2.Release()
+	var compressed bytes.Buffer
+	wCompressed := NewWriter(&compressed, WithSchema(schema), WithAllocator(mem),
+		WithFieldCodec(func(path []string, dt arrow.DataType) flatbuf.CompressionType {
+			return flatbuf.CompressionTypeLZ4_FRAME
+		}))
+	if err := wCompressed.Write(rec2); err != nil {
+		t.Fatalf("Write (compressed): %v", err)
+	}
+	if err := wCompressed.Close(); err != nil {
+		t.Fatalf("Close (compressed): %v", err)
+	}
+
+	if compressed.Len() >= uncompressed.Len() {
+		t.Fatalf("WithFieldCodec output (%d bytes) is not smaller than uncompressed output (%d bytes); field codec was not applied", compressed.Len(), uncompressed.Len())
+	}
+
+	rec3 := highlyCompressibleRecord(mem, schema, value)
+	defer rec3.Release()
+	got := readBackString(t, mem, schema, rec3,
+		WithFieldCodec(func(path []string, dt arrow.DataType) flatbuf.CompressionType {
+			return flatbuf.CompressionTypeLZ4_FRAME
+		}))
+	if got != value {
+		t.Fatalf("decoded value = %q, want %q", got, value)
+	}
+}
+
+// TestWithDictionaryCompressionLevel checks that a configured level is
+// actually used to build the dictionary's ZSTD compressor, instead of
+// silently falling back to the default level because no compressor in this
+// package implements a SetLevel method, and that a Writer using the option
+// end-to-end still produces a stream a Reader can decode.
+func TestWithDictionaryCompressionLevel(t *testing.T) {
+	codec, err := newLeveledZSTDCompressor(19)
+	if err != nil {
+		t.Fatalf("newLeveledZSTDCompressor: %v", err)
+	}
+	if codec == nil {
+		t.Fatal("newLeveledZSTDCompressor returned a nil compressor")
+	}
+
+	var buf bytes.Buffer
+	codec.Reset(&buf)
+	if _, err := codec.Write([]byte(strings.Repeat("a", 4096))); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := codec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("leveled ZSTD compressor produced no output")
+	}
+
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f0", Type: arrow.BinaryTypes.String}}, nil)
+	value := strings.Repeat("arrow-ipc-dictionary-level", 4096)
+	rec := highlyCompressibleRecord(mem, schema, value)
+	defer rec.Release()
+
+	got := readBackString(t, mem, schema, rec,
+		WithCodec(flatbuf.CompressionTypeZSTD), WithDictionaryCompressionLevel(19))
+	if got != value {
+		t.Fatalf("decoded value = %q, want %q", got, value)
+	}
+}
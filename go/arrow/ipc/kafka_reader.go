@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import "io"
+
+// KafkaConsumer is the subset of a Kafka consumer client required to
+// reassemble a stream written by a kafkaWriter. Messages must be delivered in
+// the order they were produced (i.e. a single partition, or a consumer
+// configured to preserve per-key ordering).
+type KafkaConsumer interface {
+	// Messages returns the channel of incoming messages for the consumer's
+	// configured topic(s). Implementations must close this channel once no
+	// more messages will be delivered.
+	Messages() <-chan *KafkaMessage
+	// Errors returns the channel of consumer errors, e.g. broker
+	// disconnects. A value received here terminates the reader.
+	Errors() <-chan error
+}
+
+// NewKafkaReader reassembles an Arrow IPC stream out of the messages
+// delivered by consumer and returns a Reader over it, as if the messages had
+// been concatenated back into the single byte stream a kafkaWriter split
+// them from. Use opts to pass an allocator or expected schema, as with
+// NewReader.
+//
+// consumer should deliver kafkaWriter's main topic, which starts with its own
+// schema message, so a reader starting from the beginning of the topic needs
+// nothing else. A consumer instead joining a stream already in progress
+// should consume the companion schema topic first (or otherwise recover the
+// schema) and obtain a Reader via NewReader directly.
+func NewKafkaReader(consumer KafkaConsumer, opts ...Option) (*Reader, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		for {
+			select {
+			case msg, ok := <-consumer.Messages():
+				if !ok {
+					pw.Close()
+					return
+				}
+				if _, err := pw.Write(msg.Value); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+			case err, ok := <-consumer.Errors():
+				if !ok {
+					pw.Close()
+					return
+				}
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return NewReader(pr, opts...)
+}
@@ -0,0 +1,127 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/array"
+	"github.com/apache/arrow/go/v9/arrow/ipc"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+)
+
+type fakeKafkaProducer struct {
+	messages []*ipc.KafkaMessage
+}
+
+func (p *fakeKafkaProducer) SendMessage(msg *ipc.KafkaMessage) (int32, int64, error) {
+	p.messages = append(p.messages, msg)
+	return 0, int64(len(p.messages) - 1), nil
+}
+
+type fakeKafkaConsumer struct {
+	msgs chan *ipc.KafkaMessage
+	errs chan error
+}
+
+func (c *fakeKafkaConsumer) Messages() <-chan *ipc.KafkaMessage { return c.msgs }
+func (c *fakeKafkaConsumer) Errors() <-chan error               { return c.errs }
+
+// TestKafkaWriterReaderRoundTrip checks that a reader consuming only the main
+// topic from its beginning (no access to the companion schema topic) can
+// still bootstrap the schema and decode the stream, and that dictionary
+// batches are published with the arrow-dictionary-id header.
+func TestKafkaWriterReaderRoundTrip(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	dictType := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "f0", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "f1", Type: dictType, Nullable: true},
+	}, nil)
+
+	producer := &fakeKafkaProducer{}
+	w := ipc.NewKafkaWriter(producer, "stream", ipc.WithSchema(schema), ipc.WithAllocator(mem))
+
+	ibldr := array.NewInt32Builder(mem)
+	ibldr.AppendValues([]int32{1, 2, 3}, nil)
+	iarr := ibldr.NewInt32Array()
+	ibldr.Release()
+
+	dbldr := array.NewDictionaryBuilder(mem, dictType)
+	sbldr := dbldr.(*array.BinaryDictionaryBuilder)
+	sbldr.AppendString("a")
+	sbldr.AppendString("b")
+	sbldr.AppendString("c")
+	darr := sbldr.NewArray()
+	sbldr.Release()
+
+	rec := array.NewRecord(schema, []arrow.Array{iarr, darr}, int64(iarr.Len()))
+	defer rec.Release()
+
+	if err := w.Write(rec); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var sawDictHeaders bool
+	for _, msg := range producer.messages {
+		if msg.Topic != "stream" {
+			continue
+		}
+		for _, h := range msg.Headers {
+			if string(h.Key) == ipc.KafkaHeaderDictionaryID {
+				sawDictHeaders = true
+			}
+		}
+	}
+	if !sawDictHeaders {
+		t.Fatal("expected a dictionary batch message tagged with arrow-dictionary-id header")
+	}
+
+	consumer := &fakeKafkaConsumer{
+		msgs: make(chan *ipc.KafkaMessage, len(producer.messages)),
+		errs: make(chan error),
+	}
+	for _, msg := range producer.messages {
+		if msg.Topic != "stream" {
+			// The schema-topic copy is only for consumers joining mid-stream;
+			// a reader starting from the beginning of the main topic must not
+			// need it.
+			continue
+		}
+		consumer.msgs <- msg
+	}
+	close(consumer.msgs)
+
+	r, err := ipc.NewKafkaReader(consumer, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatalf("NewKafkaReader: %v", err)
+	}
+
+	if !r.Next() {
+		t.Fatalf("expected one record batch, got none (err: %v)", r.Err())
+	}
+	if got := r.Record().NumRows(); got != 3 {
+		t.Fatalf("got %d rows, want 3", got)
+	}
+	if r.Next() {
+		t.Fatal("expected exactly one record batch")
+	}
+}
@@ -0,0 +1,341 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/arrow/go/v9/arrow/internal/flatbuf"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// defaultObjectStorePartSize is used when NewObjectStoreWriter is not given
+// an explicit part size.
+const defaultObjectStorePartSize = 8 << 20 // 8 MiB
+
+// ObjectStoreBackend is the small surface an object store (S3, GCS, Azure
+// Blob, local disk, ...) must provide for ObjectStorePayloadWriter to write
+// an Arrow IPC stream into it as a multipart object plus a footer object.
+type ObjectStoreBackend interface {
+	// Put uploads a small object (e.g. the footer) in a single call.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// NewMultipartUpload begins a multipart upload for key.
+	NewMultipartUpload(ctx context.Context, key string) (ObjectStoreMultipartUpload, error)
+}
+
+// ObjectStoreMultipartUpload is a single in-progress multipart upload. Part
+// numbers start at 1 and must be uploaded in order, as produced by
+// ObjectStorePayloadWriter.
+type ObjectStoreMultipartUpload interface {
+	UploadPart(ctx context.Context, partNumber int, r io.Reader, size int64) error
+	Complete(ctx context.Context) error
+	Abort(ctx context.Context) error
+}
+
+// objectStoreFooterEntry locates one record batch within the uploaded
+// object, so that a random-access reader can seek directly to it instead of
+// scanning the stream from the start.
+type objectStoreFooterEntry struct {
+	BatchIndex int   `json:"batch_index"`
+	Offset     int64 `json:"offset"`
+	Length     int64 `json:"length"`
+	NumRows    int64 `json:"num_rows"`
+}
+
+// objectStoreFooter is serialized as JSON to "<key>.footer" once the stream
+// is closed.
+type objectStoreFooter struct {
+	Batches []objectStoreFooterEntry `json:"batches"`
+}
+
+// objectStoreWriter is a PayloadWriter that uploads an Arrow IPC stream to an
+// ObjectStoreBackend, chunking it at record-batch boundaries into roughly
+// partSize-byte multipart upload parts. A failed part upload is not retried:
+// it aborts the whole multipart upload, and the error is returned.
+type objectStoreWriter struct {
+	ctx      context.Context
+	backend  ObjectStoreBackend
+	key      string
+	partSize int
+
+	upload     ObjectStoreMultipartUpload
+	partNum    int
+	pending    bytes.Buffer
+	totalBytes int64
+	footer     objectStoreFooter
+}
+
+// NewObjectStoreWriter returns a Writer that uploads its IPC stream to key in
+// backend, split into multipart upload parts of approximately partSize bytes
+// each (a value <= 0 selects an 8 MiB default). A companion "<key>.footer"
+// object indexing every record batch's (offset, length, numRows) is written
+// once the stream is closed.
+func NewObjectStoreWriter(ctx context.Context, backend ObjectStoreBackend, key string, partSize int, opts ...Option) *Writer {
+	if partSize <= 0 {
+		partSize = defaultObjectStorePartSize
+	}
+	osw := &objectStoreWriter{
+		ctx:      ctx,
+		backend:  backend,
+		key:      key,
+		partSize: partSize,
+	}
+	return NewWriterWithPayloadWriter(osw, opts...)
+}
+
+func (w *objectStoreWriter) Start() error {
+	upload, err := w.backend.NewMultipartUpload(w.ctx, w.key)
+	if err != nil {
+		return fmt.Errorf("arrow/ipc: could not start multipart upload for %q: %w", w.key, err)
+	}
+	w.upload = upload
+	return nil
+}
+
+func (w *objectStoreWriter) WritePayload(p Payload) error {
+	metaLen, err := writeIPCPayload(&w.pending, p)
+	if err != nil {
+		return fmt.Errorf("arrow/ipc: could not frame payload for object store upload: %w", err)
+	}
+	// writeIPCPayload reports only the framed metadata length; the body that
+	// follows it is p.size bytes, padding included.
+	n := int64(metaLen) + p.size
+
+	if p.msg == MessageRecordBatch {
+		w.footer.Batches = append(w.footer.Batches, objectStoreFooterEntry{
+			BatchIndex: len(w.footer.Batches),
+			Offset:     w.totalBytes,
+			Length:     n,
+			NumRows:    recordBatchNumRows(p.meta),
+		})
+	}
+	w.totalBytes += n
+
+	if w.pending.Len() >= w.partSize {
+		return w.flushPart()
+	}
+	return nil
+}
+
+func (w *objectStoreWriter) flushPart() error {
+	if w.pending.Len() == 0 {
+		return nil
+	}
+	if w.upload == nil {
+		// Writer never calls PayloadWriter.Start, so the multipart upload is
+		// begun here, before its first part.
+		if err := w.Start(); err != nil {
+			return err
+		}
+	}
+	w.partNum++
+	size := int64(w.pending.Len())
+	if err := w.upload.UploadPart(w.ctx, w.partNum, bytes.NewReader(w.pending.Bytes()), size); err != nil {
+		return w.abortOnErr(fmt.Errorf("arrow/ipc: could not upload part %d of %q: %w", w.partNum, w.key, err))
+	}
+	w.pending.Reset()
+	return nil
+}
+
+// abortOnErr aborts the in-progress multipart upload so it doesn't linger
+// (and keep costing storage) after err, a failure that leaves the stream
+// unfinishable, and folds any abort failure into the returned error.
+func (w *objectStoreWriter) abortOnErr(err error) error {
+	if abortErr := w.upload.Abort(w.ctx); abortErr != nil {
+		return fmt.Errorf("%w (and could not abort multipart upload for %q: %v)", err, w.key, abortErr)
+	}
+	return err
+}
+
+func (w *objectStoreWriter) Close() error {
+	eos := bytes.NewBuffer(kEOS[:])
+	w.pending.Write(eos.Bytes())
+	w.totalBytes += int64(eos.Len())
+
+	if err := w.flushPart(); err != nil {
+		return err
+	}
+	if err := w.upload.Complete(w.ctx); err != nil {
+		return w.abortOnErr(fmt.Errorf("arrow/ipc: could not complete multipart upload for %q: %w", w.key, err))
+	}
+
+	footerBytes, err := json.Marshal(w.footer)
+	if err != nil {
+		return fmt.Errorf("arrow/ipc: could not marshal footer for %q: %w", w.key, err)
+	}
+	if err := w.backend.Put(w.ctx, w.key+".footer", bytes.NewReader(footerBytes), int64(len(footerBytes))); err != nil {
+		return fmt.Errorf("arrow/ipc: could not upload footer for %q: %w", w.key, err)
+	}
+	return nil
+}
+
+// recordBatchNumRows decodes the row count out of a Payload's metadata
+// buffer, returning 0 for anything it can't parse (e.g. a schema message,
+// which carries no row count).
+//
+// meta holds the bare flatbuffers Message, not yet wrapped in the
+// continuation+length framing that writeIPCPayload prepends for the wire, so
+// it is parsed from offset 0.
+func recordBatchNumRows(meta *memory.Buffer) int64 {
+	if meta == nil || meta.Len() == 0 {
+		return 0
+	}
+
+	msg := flatbuf.GetRootAsMessage(meta.Bytes(), 0)
+	if msg.HeaderType() != flatbuf.MessageHeaderRecordBatch {
+		return 0
+	}
+
+	var table flatbuffers.Table
+	if !msg.Header(&table) {
+		return 0
+	}
+
+	var rb flatbuf.RecordBatch
+	rb.Init(table.Bytes, table.Pos)
+	return rb.Length()
+}
+
+// localFSBackend is an ObjectStoreBackend that writes objects as files
+// rooted at dir, useful for local testing or for pipelines that treat a
+// mounted filesystem as their "object store".
+type localFSBackend struct {
+	dir string
+}
+
+// NewLocalFSBackend returns an ObjectStoreBackend that stores objects as
+// files under dir, creating dir if it does not already exist.
+func NewLocalFSBackend(dir string) (ObjectStoreBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("arrow/ipc: could not create object store directory %q: %w", dir, err)
+	}
+	return &localFSBackend{dir: dir}, nil
+}
+
+func (b *localFSBackend) Put(_ context.Context, key string, r io.Reader, _ int64) error {
+	f, err := os.Create(filepath.Join(b.dir, key))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (b *localFSBackend) NewMultipartUpload(_ context.Context, key string) (ObjectStoreMultipartUpload, error) {
+	f, err := os.Create(filepath.Join(b.dir, key))
+	if err != nil {
+		return nil, err
+	}
+	return &localFSMultipartUpload{f: f}, nil
+}
+
+// localFSMultipartUpload appends parts to the destination file in order;
+// local disk has no real multipart API, so "uploading a part" is simply
+// writing the next contiguous range.
+type localFSMultipartUpload struct {
+	f *os.File
+}
+
+func (u *localFSMultipartUpload) UploadPart(_ context.Context, _ int, r io.Reader, _ int64) error {
+	_, err := io.Copy(u.f, r)
+	return err
+}
+
+func (u *localFSMultipartUpload) Complete(context.Context) error {
+	return u.f.Close()
+}
+
+func (u *localFSMultipartUpload) Abort(context.Context) error {
+	defer os.Remove(u.f.Name())
+	return u.f.Close()
+}
+
+// S3API is the subset of an S3-shaped client (e.g. *s3.Client from
+// aws-sdk-go-v2) required by S3Backend. Credential/region/endpoint plumbing
+// is left entirely to the caller's constructed client.
+type S3API interface {
+	PutObject(ctx context.Context, bucket, key string, r io.Reader, size int64) error
+	CreateMultipartUpload(ctx context.Context, bucket, key string) (uploadID string, err error)
+	UploadPart(ctx context.Context, bucket, key, uploadID string, partNumber int, r io.Reader, size int64) (eTag string, err error)
+	CompleteMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []S3CompletedPart) error
+	AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error
+}
+
+// S3CompletedPart records the part number and ETag needed to complete an S3
+// multipart upload.
+type S3CompletedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// S3Backend adapts an S3API client into an ObjectStoreBackend for a single
+// bucket.
+type S3Backend struct {
+	API    S3API
+	Bucket string
+}
+
+// NewS3Backend returns an ObjectStoreBackend backed by api, writing objects
+// into bucket.
+func NewS3Backend(api S3API, bucket string) *S3Backend {
+	return &S3Backend{API: api, Bucket: bucket}
+}
+
+func (b *S3Backend) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	return b.API.PutObject(ctx, b.Bucket, key, r, size)
+}
+
+func (b *S3Backend) NewMultipartUpload(ctx context.Context, key string) (ObjectStoreMultipartUpload, error) {
+	uploadID, err := b.API.CreateMultipartUpload(ctx, b.Bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return &s3MultipartUpload{api: b.API, bucket: b.Bucket, key: key, uploadID: uploadID}, nil
+}
+
+type s3MultipartUpload struct {
+	api      S3API
+	bucket   string
+	key      string
+	uploadID string
+	parts    []S3CompletedPart
+}
+
+func (u *s3MultipartUpload) UploadPart(ctx context.Context, partNumber int, r io.Reader, size int64) error {
+	eTag, err := u.api.UploadPart(ctx, u.bucket, u.key, u.uploadID, partNumber, r, size)
+	if err != nil {
+		return err
+	}
+	u.parts = append(u.parts, S3CompletedPart{PartNumber: partNumber, ETag: eTag})
+	return nil
+}
+
+func (u *s3MultipartUpload) Complete(ctx context.Context) error {
+	return u.api.CompleteMultipartUpload(ctx, u.bucket, u.key, u.uploadID, u.parts)
+}
+
+func (u *s3MultipartUpload) Abort(ctx context.Context) error {
+	return u.api.AbortMultipartUpload(ctx, u.bucket, u.key, u.uploadID)
+}
@@ -0,0 +1,151 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/array"
+	"github.com/apache/arrow/go/v9/arrow/ipc"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+)
+
+// objectStoreFooter mirrors the JSON shape ipc writes to "<key>.footer",
+// since the writer's own footer type is unexported.
+type objectStoreFooter struct {
+	Batches []struct {
+		BatchIndex int   `json:"batch_index"`
+		Offset     int64 `json:"offset"`
+		Length     int64 `json:"length"`
+		NumRows    int64 `json:"num_rows"`
+	} `json:"batches"`
+}
+
+func TestObjectStoreWriterReaderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := ipc.NewLocalFSBackend(dir)
+	if err != nil {
+		t.Fatalf("NewLocalFSBackend: %v", err)
+	}
+
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f0", Type: arrow.PrimitiveTypes.Int32}}, nil)
+	w := ipc.NewObjectStoreWriter(context.Background(), backend, "stream", 0, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+
+	bldr := array.NewInt32Builder(mem)
+	bldr.AppendValues([]int32{1, 2, 3, 4}, nil)
+	arr := bldr.NewInt32Array()
+	bldr.Release()
+	rec := array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	if err := w.Write(rec); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "stream"))
+	if err != nil {
+		t.Fatalf("open uploaded object: %v", err)
+	}
+	defer f.Close()
+
+	r, err := ipc.NewReader(f, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if !r.Next() {
+		t.Fatalf("expected one record batch, got none (err: %v)", r.Err())
+	}
+	if got := r.Record().NumRows(); got != 4 {
+		t.Fatalf("got %d rows, want 4", got)
+	}
+
+	footerBytes, err := os.ReadFile(filepath.Join(dir, "stream.footer"))
+	if err != nil {
+		t.Fatalf("expected a footer object: %v", err)
+	}
+	var footer objectStoreFooter
+	if err := json.Unmarshal(footerBytes, &footer); err != nil {
+		t.Fatalf("could not decode footer: %v", err)
+	}
+	if len(footer.Batches) != 1 {
+		t.Fatalf("got %d footer batch entries, want 1", len(footer.Batches))
+	}
+	if got := footer.Batches[0].NumRows; got != 4 {
+		t.Fatalf("footer batch NumRows = %d, want 4", got)
+	}
+}
+
+// failingMultipartBackend fails every UploadPart call, to check that the
+// Writer aborts the multipart upload instead of leaving it dangling.
+type failingMultipartBackend struct {
+	aborted bool
+}
+
+func (b *failingMultipartBackend) Put(context.Context, string, io.Reader, int64) error { return nil }
+
+func (b *failingMultipartBackend) NewMultipartUpload(context.Context, string) (ipc.ObjectStoreMultipartUpload, error) {
+	return &failingMultipartUpload{backend: b}, nil
+}
+
+type failingMultipartUpload struct {
+	backend *failingMultipartBackend
+}
+
+func (u *failingMultipartUpload) UploadPart(context.Context, int, io.Reader, int64) error {
+	return errors.New("boom: upload part failed")
+}
+
+func (u *failingMultipartUpload) Complete(context.Context) error { return nil }
+
+func (u *failingMultipartUpload) Abort(context.Context) error {
+	u.backend.aborted = true
+	return nil
+}
+
+func TestObjectStoreWriterAbortsOnUploadFailure(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f0", Type: arrow.PrimitiveTypes.Int32}}, nil)
+	backend := &failingMultipartBackend{}
+	// partSize=1 forces the very first payload write to flush, and fail.
+	w := ipc.NewObjectStoreWriter(context.Background(), backend, "stream", 1, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+
+	bldr := array.NewInt32Builder(mem)
+	bldr.AppendValues([]int32{1}, nil)
+	arr := bldr.NewInt32Array()
+	bldr.Release()
+	rec := array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	if err := w.Write(rec); err == nil {
+		t.Fatal("expected Write to report the upload failure")
+	}
+
+	if !backend.aborted {
+		t.Fatal("expected the multipart upload to be aborted after the failed part")
+	}
+}
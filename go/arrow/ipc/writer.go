@@ -88,6 +88,80 @@ type Writer struct {
 	// so we can avoid writing the same dictionary over and over
 	lastWrittenDicts map[int64]arrow.Array
 	emitDictDeltas   bool
+
+	// compressPool, when compressNP > 1, holds the long-lived compression
+	// workers shared by every Write call instead of spinning up goroutines
+	// and codec instances per call.
+	compressPool *compressorPool
+
+	// writeQueue and drainWG pipeline encode/compress (on the caller's
+	// goroutine and compressPool's workers) against PayloadWriter.WritePayload
+	// (on drainWrites' goroutine), so that a slow payload writer overlaps
+	// with encoding/compressing the next record instead of blocking it.
+	writeQueue chan *writeJob
+	drainWG    sync.WaitGroup
+
+	asyncMu  sync.Mutex
+	asyncErr error
+
+	// cdc, when set via WithContentDefinedChunking, is threaded into every
+	// recordEncoder so Binary/String value buffers get rechunked before
+	// compression instead of compressed in one shot.
+	cdc *cdcParams
+
+	// fieldCodec, strictFieldCodec and dictCodecLevel are threaded into every
+	// recordEncoder; see WithFieldCodec, WithStrictFieldCodec and
+	// WithDictionaryCompressionLevel.
+	fieldCodec       func(path []string, dt arrow.DataType) flatbuf.CompressionType
+	strictFieldCodec bool
+	dictCodecLevel   int
+}
+
+// writeJob carries one payload through the pipeline: the caller's goroutine
+// fills in payload (once compression finishes, which may be asynchronous)
+// and signals done; drainWrites consumes jobs off writeQueue in submission
+// order, so payloads reach the payload writer in the order they were
+// submitted even though later stages may finish early. Dictionary batches
+// are encoded synchronously and so arrive with done already signalled; they
+// are still routed through writeQueue, ahead of the record job for the same
+// Write call, so a dictionary replacement can never overtake an
+// earlier-submitted record batch that is still waiting to be drained.
+type writeJob struct {
+	payload Payload
+	done    chan error
+}
+
+// queuedPayloadWriter adapts Writer.enqueue to the PayloadWriter interface so
+// writeDictionaryPayloads can submit dictionary batches through the same
+// ordered writeQueue used for record batches, instead of writing them
+// straight to pw.
+type queuedPayloadWriter struct {
+	w *Writer
+}
+
+func (q *queuedPayloadWriter) Start() error { return q.w.pw.Start() }
+func (q *queuedPayloadWriter) Close() error { return q.w.pw.Close() }
+func (q *queuedPayloadWriter) WritePayload(p Payload) error {
+	// Dictionary batches are fully encoded (and, if configured, compressed)
+	// by the time writeDictionaryPayloads calls this, so done is already
+	// resolved; only the ordering against writeQueue matters here.
+	//
+	// writeDictionaryPayloads releases its own reference to p once this
+	// function returns, so retain the buffers for the job queued here;
+	// drainWrites releases that reference once the job has been written.
+	if p.meta != nil {
+		p.meta.Retain()
+	}
+	for _, buf := range p.body {
+		if buf != nil {
+			buf.Retain()
+		}
+	}
+
+	done := make(chan error, 1)
+	done <- nil
+	q.w.writeQueue <- &writeJob{payload: p, done: done}
+	return nil
 }
 
 // NewWriterWithPayloadWriter constructs a writer with the provided payload writer
@@ -96,11 +170,15 @@ type Writer struct {
 func NewWriterWithPayloadWriter(pw PayloadWriter, opts ...Option) *Writer {
 	cfg := newConfig(opts...)
 	return &Writer{
-		mem:        cfg.alloc,
-		pw:         pw,
-		schema:     cfg.schema,
-		codec:      cfg.codec,
-		compressNP: cfg.compressNP,
+		mem:              cfg.alloc,
+		pw:               pw,
+		schema:           cfg.schema,
+		codec:            cfg.codec,
+		compressNP:       cfg.compressNP,
+		cdc:              cfg.cdc,
+		fieldCodec:       cfg.fieldCodec,
+		strictFieldCodec: cfg.strictFieldCodec,
+		dictCodecLevel:   cfg.dictCodecLevel,
 	}
 }
 
@@ -108,11 +186,15 @@ func NewWriterWithPayloadWriter(pw PayloadWriter, opts ...Option) *Writer {
 func NewWriter(w io.Writer, opts ...Option) *Writer {
 	cfg := newConfig(opts...)
 	return &Writer{
-		w:      w,
-		mem:    cfg.alloc,
-		pw:     &swriter{w: w},
-		schema: cfg.schema,
-		codec:  cfg.codec,
+		w:                w,
+		mem:              cfg.alloc,
+		pw:               &swriter{w: w},
+		schema:           cfg.schema,
+		codec:            cfg.codec,
+		cdc:              cfg.cdc,
+		fieldCodec:       cfg.fieldCodec,
+		strictFieldCodec: cfg.strictFieldCodec,
+		dictCodecLevel:   cfg.dictCodecLevel,
 	}
 }
 
@@ -124,23 +206,86 @@ func (w *Writer) Close() error {
 		}
 	}
 
-	if w.pw == nil {
-		return nil
+	if w.writeQueue != nil {
+		close(w.writeQueue)
+		w.drainWG.Wait()
+		w.writeQueue = nil
+	}
+	if w.compressPool != nil {
+		w.compressPool.close()
+		w.compressPool = nil
 	}
 
-	err := w.pw.Close()
-	if err != nil {
-		return fmt.Errorf("arrow/ipc: could not close payload writer: %w", err)
+	asyncErr := w.takeAsyncErr()
+
+	// Run cleanup (closing pw, releasing retained dictionaries) even when an
+	// async error was recorded: an error from a background write must not
+	// leak the payload writer or the dictionary cache.
+	var closeErr error
+	if w.pw != nil {
+		closeErr = w.pw.Close()
+		w.pw = nil
 	}
-	w.pw = nil
 
 	for _, d := range w.lastWrittenDicts {
 		d.Release()
 	}
+	w.lastWrittenDicts = nil
+
+	if asyncErr != nil {
+		return asyncErr
+	}
+	if closeErr != nil {
+		return fmt.Errorf("arrow/ipc: could not close payload writer: %w", closeErr)
+	}
 
 	return nil
 }
 
+func (w *Writer) setAsyncErr(err error) {
+	w.asyncMu.Lock()
+	if w.asyncErr == nil {
+		w.asyncErr = err
+	}
+	w.asyncMu.Unlock()
+}
+
+func (w *Writer) takeAsyncErr() error {
+	w.asyncMu.Lock()
+	defer w.asyncMu.Unlock()
+	return w.asyncErr
+}
+
+// drainWrites is the single goroutine allowed to call w.pw.WritePayload, for
+// both dictionary and record batches. It processes jobs strictly in the
+// order they were submitted to writeQueue, waiting on each job's done
+// channel so a batch whose compression is still running doesn't get
+// reordered ahead of an earlier one.
+func (w *Writer) drainWrites() {
+	defer w.drainWG.Done()
+	for job := range w.writeQueue {
+		if err := <-job.done; err != nil {
+			w.setAsyncErr(err)
+			job.payload.Release()
+			continue
+		}
+
+		if err := w.pw.WritePayload(job.payload); err != nil {
+			w.setAsyncErr(fmt.Errorf("arrow/ipc: could not write payload: %w", err))
+		}
+		job.payload.Release()
+	}
+}
+
+// Write encodes rec and hands it to the PayloadWriter. Encoding happens
+// before Write returns, and so does compression unless the Writer runs a
+// shared compression pool (compressNP > 1), in which case the record batch's
+// buffers may still be compressing on the pool's workers. The actual
+// PayloadWriter.WritePayload call happens on drainWrites' goroutine, so a
+// failure there, or in pooled compression, is only surfaced by the next
+// Write call or by Close, not by this call. Callers that require a write error to be reported synchronously
+// (e.g. to retry immediately or to fail a request in progress) must check
+// the return value of their next Write or of Close.
 func (w *Writer) Write(rec arrow.Record) (err error) {
 	defer func() {
 		if pErr := recover(); pErr != nil {
@@ -155,29 +300,74 @@ func (w *Writer) Write(rec arrow.Record) (err error) {
 		}
 	}
 
+	if err := w.takeAsyncErr(); err != nil {
+		return err
+	}
+
 	schema := rec.Schema()
 	if schema == nil || !schema.Equal(w.schema) {
 		return errInconsistentSchema
 	}
 
 	const allow64b = true
-	var (
-		data = Payload{msg: MessageRecordBatch}
-		enc  = newRecordEncoder(w.mem, 0, kMaxNestingDepth, allow64b, w.codec, w.compressNP)
-	)
-	defer data.Release()
-
-	err = writeDictionaryPayloads(w.mem, rec, false, w.emitDictDeltas, &w.mapper, w.lastWrittenDicts, w.pw, enc)
+	enc := newRecordEncoder(w.mem, 0, kMaxNestingDepth, allow64b, w.codec, w.compressNP)
+	enc.pool = w.compressPool
+	enc.cdc = w.cdc
+	enc.fieldCodec = w.fieldCodec
+	enc.strictFieldCodec = w.strictFieldCodec
+	enc.dictCodecLevel = w.dictCodecLevel
+
+	dictPW := &queuedPayloadWriter{w: w}
+	err = writeDictionaryPayloads(w.mem, rec, false, w.emitDictDeltas, &w.mapper, w.lastWrittenDicts, dictPW, enc)
 	if err != nil {
 		return fmt.Errorf("arrow/ipc: failure writing dictionary batches: %w", err)
 	}
 
 	enc.reset()
-	if err := enc.Encode(&data, rec); err != nil {
+	job := &writeJob{payload: Payload{msg: MessageRecordBatch}, done: make(chan error, 1)}
+	if err := enc.encodeVisit(&job.payload, rec); err != nil {
+		job.payload.Release()
 		return fmt.Errorf("arrow/ipc: could not encode record to payload: %w", err)
 	}
 
-	return w.pw.WritePayload(data)
+	nrows := rec.NumRows()
+	finish := func(compressErr error) {
+		// finish may run on a compressorPool worker goroutine (the async
+		// pool path) rather than the goroutine that called Write, so a
+		// panic out of encodeFinalize (e.g. finalizeOffsets' alignment
+		// check) must be recovered here instead of relying on Write's own
+		// recover, or it would crash the process.
+		defer func() {
+			if r := recover(); r != nil {
+				job.done <- fmt.Errorf("arrow/ipc: panic while finalizing payload: %v", r)
+			}
+		}()
+
+		if compressErr != nil {
+			job.done <- compressErr
+			return
+		}
+		job.done <- enc.encodeFinalize(&job.payload, nrows)
+	}
+
+	switch {
+	case w.codec == -1 && enc.fieldCodec == nil:
+		finish(nil)
+	case enc.pool != nil:
+		codecs, err := enc.resolveBufferCodecs(&job.payload)
+		if err != nil {
+			job.payload.Release()
+			return fmt.Errorf("arrow/ipc: could not resolve field compression codecs: %w", err)
+		}
+		enc.pool.compressPayloadAsync(&job.payload, codecs, 0, enc.cdcValueBufs, enc.cdc, finish)
+	default:
+		finish(enc.compressBodyBuffers(&job.payload))
+	}
+
+	// blocks if the pipeline is already full, bounding how far ahead of the
+	// payload writer the caller can get.
+	w.writeQueue <- job
+	return nil
 }
 
 func writeDictionaryPayloads(mem memory.Allocator, batch arrow.Record, isFileFormat bool, emitDictDeltas bool, mapper *dictutils.Mapper, lastWrittenDicts map[int64]arrow.Array, pw PayloadWriter, encoder *recordEncoder) error {
@@ -263,6 +453,18 @@ func (w *Writer) start() error {
 		}
 	}
 
+	if w.compressNP > 1 {
+		w.compressPool = newCompressorPool(w.compressNP)
+	}
+
+	queueDepth := w.compressNP
+	if queueDepth < 1 {
+		queueDepth = 1
+	}
+	w.writeQueue = make(chan *writeJob, queueDepth)
+	w.drainWG.Add(1)
+	go w.drainWrites()
+
 	return nil
 }
 
@@ -271,14 +473,16 @@ type dictEncoder struct {
 }
 
 func (d *dictEncoder) encodeMetadata(p *Payload, isDelta bool, id, nrows int64) error {
-	p.meta = writeDictionaryMessage(d.mem, id, isDelta, nrows, p.size, d.fields, d.meta, d.codec)
+	p.meta = writeDictionaryMessage(d.mem, id, isDelta, nrows, p.size, d.fields, d.meta, d.batchCodec)
 	return nil
 }
 
 func (d *dictEncoder) Encode(p *Payload, id int64, isDelta bool, dict arrow.Array) error {
 	d.start = 0
+	d.isDictBatch = true
 	defer func() {
 		d.start = 0
+		d.isDictBatch = false
 	}()
 
 	schema := arrow.NewSchema([]arrow.Field{{Name: "dictionary", Type: dict.DataType(), Nullable: true}}, nil)
@@ -302,6 +506,42 @@ type recordEncoder struct {
 	allow64b   bool
 	codec      flatbuf.CompressionType
 	compressNP int
+
+	// pool, when set, is the Writer's shared compressorPool; compression is
+	// dispatched to it instead of the per-call goroutines below.
+	pool *compressorPool
+
+	// cdc, when set, enables content-defined chunking of the value buffers
+	// whose indices are recorded in cdcValueBufs (populated by visit for
+	// Binary/String/Large* columns).
+	cdc          *cdcParams
+	cdcValueBufs map[int]bool
+
+	// fieldCodec, when set via WithFieldCodec, picks the compression codec
+	// for the buffer of the field currently being visited, keyed by its
+	// dotted path from the record root. path is pushed/popped as visit
+	// descends into struct children. bufCodecs mirrors p.body index-for-index
+	// once fieldCodec is set, recorded by appendBody.
+	fieldCodec func(path []string, dt arrow.DataType) flatbuf.CompressionType
+	path       []string
+
+	currentCodec     flatbuf.CompressionType
+	bufCodecs        []flatbuf.CompressionType
+	strictFieldCodec bool
+
+	// batchCodec is the codec actually recorded in the record batch's
+	// BodyCompression metadata. It defaults to codec and is only overridden,
+	// by resolveBufferCodecs, when fieldCodec picked a single non-default
+	// codec uniformly across every buffer of the batch.
+	batchCodec flatbuf.CompressionType
+
+	// dictCodecLevel, when > 0, is the ZSTD level used in place of the
+	// default for a dictionary's buffers instead of the default level,
+	// since dictionaries are written once and reused by every batch that
+	// references them. isDictBatch is set by dictEncoder.Encode for the
+	// duration of a dictionary's encode+compress call.
+	dictCodecLevel int
+	isDictBatch    bool
 }
 
 func newRecordEncoder(mem memory.Allocator, startOffset, maxDepth int64, allow64b bool, codec flatbuf.CompressionType, compressNP int) *recordEncoder {
@@ -311,6 +551,7 @@ func newRecordEncoder(mem memory.Allocator, startOffset, maxDepth int64, allow64
 		depth:      maxDepth,
 		allow64b:   allow64b,
 		codec:      codec,
+		batchCodec: codec,
 		compressNP: compressNP,
 	}
 }
@@ -318,9 +559,95 @@ func newRecordEncoder(mem memory.Allocator, startOffset, maxDepth int64, allow64
 func (w *recordEncoder) reset() {
 	w.start = 0
 	w.fields = make([]fieldMetadata, 0)
+	w.cdcValueBufs = nil
+	w.bufCodecs = nil
+	w.path = nil
+	w.batchCodec = w.codec
+}
+
+// appendBody appends buf to p.body and, if a per-field codec selector is
+// configured, records which codec owns it (currentCodec, set by visit just
+// before it descends into a field's buffers), keeping bufCodecs aligned with
+// p.body index-for-index.
+func (w *recordEncoder) appendBody(p *Payload, buf *memory.Buffer) {
+	p.body = append(p.body, buf)
+	if w.fieldCodec == nil {
+		return
+	}
+	w.bufCodecs = append(w.bufCodecs, w.currentCodec)
+}
+
+func (w *recordEncoder) pushPath(name string) { w.path = append(w.path, name) }
+func (w *recordEncoder) popPath()             { w.path = w.path[:len(w.path)-1] }
+
+// markCDCEligible records that p.body[idx] is a Binary/String-family value
+// buffer, so compressBodyBuffers (or the owning Writer's compressorPool) may
+// rechunk it at content-defined boundaries before compression.
+func (w *recordEncoder) markCDCEligible(idx int) {
+	if w.cdc == nil {
+		return
+	}
+	if w.cdcValueBufs == nil {
+		w.cdcValueBufs = make(map[int]bool)
+	}
+	w.cdcValueBufs[idx] = true
+}
+
+// resolveBufferCodecs returns the compression codec to use for each buffer
+// in p.body, and updates w.batchCodec to the codec that must be recorded in
+// the batch's BodyCompression metadata.
+//
+// With no fieldCodec configured, every buffer uses w.codec. With fieldCodec
+// configured, its choices are honored only if they turn out identical across
+// every buffer of the batch, since the IPC format has no way to record more
+// than one codec per batch; if they differ, every buffer falls back to
+// w.codec instead, unless strictFieldCodec is set, in which case mixed
+// codecs are reported as an error rather than silently downgraded.
+func (w *recordEncoder) resolveBufferCodecs(p *Payload) ([]flatbuf.CompressionType, error) {
+	w.batchCodec = w.codec
+
+	if w.fieldCodec == nil || len(w.bufCodecs) != len(p.body) || len(w.bufCodecs) == 0 {
+		codecs := make([]flatbuf.CompressionType, len(p.body))
+		for i := range codecs {
+			codecs[i] = w.codec
+		}
+		return codecs, nil
+	}
+
+	uniform := w.bufCodecs[0]
+	for _, c := range w.bufCodecs[1:] {
+		if c == uniform {
+			continue
+		}
+		if w.strictFieldCodec {
+			return nil, fmt.Errorf("arrow/ipc: WithFieldCodec chose different codecs across fields of one record batch, but the IPC format only records a single codec per batch; disable WithStrictFieldCodec to fall back to %v instead", w.codec)
+		}
+		codecs := make([]flatbuf.CompressionType, len(p.body))
+		for i := range codecs {
+			codecs[i] = w.codec
+		}
+		return codecs, nil
+	}
+
+	w.batchCodec = uniform
+	return w.bufCodecs, nil
 }
 
 func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
+	codecs, err := w.resolveBufferCodecs(p)
+	if err != nil {
+		return err
+	}
+
+	level := 0
+	if w.isDictBatch {
+		level = w.dictCodecLevel
+	}
+
+	if w.pool != nil {
+		return w.pool.compressPayload(p, codecs, level, w.cdcValueBufs, w.cdc)
+	}
+
 	compress := func(idx int, codec compressor) error {
 		if p.body[idx] == nil || p.body[idx].Len() == 0 {
 			return nil
@@ -331,7 +658,11 @@ func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
 			return err
 		}
 		codec.Reset(&buf)
-		if _, err := codec.Write(p.body[idx].Bytes()); err != nil {
+		if w.cdc != nil && isChunkableCodec(codecs[idx]) && w.cdcValueBufs[idx] {
+			if err := writeChunked(codec, p.body[idx].Bytes(), w.cdc); err != nil {
+				return err
+			}
+		} else if _, err := codec.Write(p.body[idx].Bytes()); err != nil {
 			return err
 		}
 		if err := codec.Close(); err != nil {
@@ -341,9 +672,41 @@ func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
 		return nil
 	}
 
+	// newCodec builds (and, at a configured dictionary level, tunes) a
+	// compressor for c, caching it per codec type so a worker touching
+	// several differently-coded buffers doesn't pay construction cost twice
+	// for the same codec.
+	newCodec := func(cache map[flatbuf.CompressionType]compressor, c flatbuf.CompressionType) (compressor, error) {
+		if codec, ok := cache[c]; ok {
+			return codec, nil
+		}
+		var (
+			codec compressor
+			err   error
+		)
+		if level > 0 && c == flatbuf.CompressionTypeZSTD {
+			codec, err = newLeveledZSTDCompressor(level)
+		} else {
+			codec = getCompressor(c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		cache[c] = codec
+		return codec, nil
+	}
+
 	if w.compressNP <= 1 {
-		codec := getCompressor(w.codec)
+		cache := make(map[flatbuf.CompressionType]compressor, 1)
 		for idx := range p.body {
+			if codecs[idx] == -1 {
+				// fieldCodec chose to leave this buffer uncompressed.
+				continue
+			}
+			codec, err := newCodec(cache, codecs[idx])
+			if err != nil {
+				return err
+			}
 			if err := compress(idx, codec); err != nil {
 				return err
 			}
@@ -363,7 +726,7 @@ func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			codec := getCompressor(w.codec)
+			cache := make(map[flatbuf.CompressionType]compressor)
 			for {
 				select {
 				case idx, ok := <-ch:
@@ -371,8 +734,16 @@ func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
 						// we're done, channel is closed!
 						return
 					}
+					if codecs[idx] == -1 {
+						// fieldCodec chose to leave this buffer uncompressed.
+						continue
+					}
 
-					if err := compress(idx, codec); err != nil {
+					codec, err := newCodec(cache, codecs[idx])
+					if err == nil {
+						err = compress(idx, codec)
+					}
+					if err != nil {
 						errch <- err
 						cancel()
 						return
@@ -396,20 +767,26 @@ func (w *recordEncoder) compressBodyBuffers(p *Payload) error {
 	return <-errch
 }
 
-func (w *recordEncoder) encode(p *Payload, rec arrow.Record) error {
-
-	// perform depth-first traversal of the row-batch
+// encodeVisit performs the depth-first traversal of the row-batch, appending
+// each column's buffers to p.body uncompressed. It does not touch
+// compression or metadata, so that callers can dispatch compression (e.g.
+// to a compressorPool) before finishing the encode with finalizeOffsets.
+func (w *recordEncoder) encodeVisit(p *Payload, rec arrow.Record) error {
 	for i, col := range rec.Columns() {
+		w.pushPath(rec.ColumnName(i))
 		err := w.visit(p, col)
+		w.popPath()
 		if err != nil {
 			return fmt.Errorf("arrow/ipc: could not encode column %d (%q): %w", i, rec.ColumnName(i), err)
 		}
 	}
+	return nil
+}
 
-	if w.codec != -1 {
-		w.compressBodyBuffers(p)
-	}
-
+// finalizeOffsets computes each body buffer's offset/padding now that their
+// (possibly compressed) final sizes are known, and records them as the
+// record batch's buffer metadata.
+func (w *recordEncoder) finalizeOffsets(p *Payload) error {
 	// position for the start of a buffer relative to the passed frame of reference.
 	// may be 0 or some other position in an address space.
 	offset := w.start
@@ -443,6 +820,30 @@ func (w *recordEncoder) encode(p *Payload, rec arrow.Record) error {
 	return nil
 }
 
+// encodeFinalize finishes encoding a payload whose buffers have already been
+// compressed (by the caller, e.g. via compressorPool.compressPayloadAsync):
+// it computes buffer offsets and builds the record batch metadata.
+func (w *recordEncoder) encodeFinalize(p *Payload, nrows int64) error {
+	if err := w.finalizeOffsets(p); err != nil {
+		return err
+	}
+	return w.encodeMetadata(p, nrows)
+}
+
+func (w *recordEncoder) encode(p *Payload, rec arrow.Record) error {
+	if err := w.encodeVisit(p, rec); err != nil {
+		return err
+	}
+
+	if w.codec != -1 || w.fieldCodec != nil {
+		if err := w.compressBodyBuffers(p); err != nil {
+			return err
+		}
+	}
+
+	return w.finalizeOffsets(p)
+}
+
 func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 	if w.depth <= 0 {
 		return errMaxRecursion
@@ -473,6 +874,10 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 		Offset: 0,
 	})
 
+	if w.fieldCodec != nil {
+		w.currentCodec = w.fieldCodec(w.path, arr.DataType())
+	}
+
 	if arr.DataType().ID() == arrow.NULL {
 		return nil
 	}
@@ -480,7 +885,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 	switch arr.NullN() {
 	case 0:
 		// there are no null values, drop the null bitmap
-		p.body = append(p.body, nil)
+		w.appendBody(p, nil)
 	default:
 		data := arr.Data()
 		var bitmap *memory.Buffer
@@ -493,7 +898,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 			// otherwise truncate and copy the bits
 			bitmap = newTruncatedBitmap(w.mem, int64(data.Offset()), int64(data.Len()), data.Buffers()[0])
 		}
-		p.body = append(p.body, bitmap)
+		w.appendBody(p, bitmap)
 	}
 
 	switch dtype := arr.DataType().(type) {
@@ -509,7 +914,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 		if data.Len() != 0 {
 			bitm = newTruncatedBitmap(w.mem, int64(data.Offset()), int64(data.Len()), data.Buffers()[1])
 		}
-		p.body = append(p.body, bitm)
+		w.appendBody(p, bitm)
 
 	case arrow.FixedWidthDataType:
 		data := arr.Data()
@@ -530,7 +935,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 				values.Retain()
 			}
 		}
-		p.body = append(p.body, values)
+		w.appendBody(p, values)
 
 	case *arrow.BinaryType:
 		arr := arr.(*array.Binary)
@@ -559,8 +964,9 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 				values.Retain()
 			}
 		}
-		p.body = append(p.body, voffsets)
-		p.body = append(p.body, values)
+		w.appendBody(p, voffsets)
+		w.appendBody(p, values)
+		w.markCDCEligible(len(p.body) - 1)
 
 	case *arrow.StringType:
 		arr := arr.(*array.String)
@@ -589,14 +995,79 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 				values.Retain()
 			}
 		}
-		p.body = append(p.body, voffsets)
-		p.body = append(p.body, values)
+		w.appendBody(p, voffsets)
+		w.appendBody(p, values)
+		w.markCDCEligible(len(p.body) - 1)
+
+	case *arrow.LargeBinaryType:
+		arr := arr.(*array.LargeBinary)
+		voffsets, err := w.getZeroBasedValueOffsets64(arr)
+		if err != nil {
+			return fmt.Errorf("could not retrieve zero-based value offsets from %T: %w", arr, err)
+		}
+		data := arr.Data()
+		values := data.Buffers()[2]
+
+		var totalDataBytes int64
+		if voffsets != nil {
+			totalDataBytes = int64(len(arr.ValueBytes()))
+		}
+
+		switch {
+		case needTruncate(int64(data.Offset()), values, totalDataBytes):
+			// slice data buffer to include the range we need now.
+			var (
+				beg = arr.ValueOffset(0)
+				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(totalDataBytes))
+			)
+			values = memory.NewBufferBytes(data.Buffers()[2].Bytes()[beg : beg+len])
+		default:
+			if values != nil {
+				values.Retain()
+			}
+		}
+		w.appendBody(p, voffsets)
+		w.appendBody(p, values)
+		w.markCDCEligible(len(p.body) - 1)
+
+	case *arrow.LargeStringType:
+		arr := arr.(*array.LargeString)
+		voffsets, err := w.getZeroBasedValueOffsets64(arr)
+		if err != nil {
+			return fmt.Errorf("could not retrieve zero-based value offsets from %T: %w", arr, err)
+		}
+		data := arr.Data()
+		values := data.Buffers()[2]
+
+		var totalDataBytes int64
+		if voffsets != nil {
+			totalDataBytes = int64(len(arr.ValueBytes()))
+		}
+
+		switch {
+		case needTruncate(int64(data.Offset()), values, totalDataBytes):
+			// slice data buffer to include the range we need now.
+			var (
+				beg = arr.ValueOffset(0)
+				len = minI64(paddedLength(totalDataBytes, kArrowAlignment), int64(totalDataBytes))
+			)
+			values = memory.NewBufferBytes(data.Buffers()[2].Bytes()[beg : beg+len])
+		default:
+			if values != nil {
+				values.Retain()
+			}
+		}
+		w.appendBody(p, voffsets)
+		w.appendBody(p, values)
+		w.markCDCEligible(len(p.body) - 1)
 
 	case *arrow.StructType:
 		w.depth--
 		arr := arr.(*array.Struct)
 		for i := 0; i < arr.NumField(); i++ {
+			w.pushPath(dtype.Field(i).Name)
 			err := w.visit(p, arr.Field(i))
+			w.popPath()
 			if err != nil {
 				return fmt.Errorf("could not visit field %d of struct-array: %w", i, err)
 			}
@@ -609,7 +1080,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 		if err != nil {
 			return fmt.Errorf("could not retrieve zero-based value offsets for array %T: %w", arr, err)
 		}
-		p.body = append(p.body, voffsets)
+		w.appendBody(p, voffsets)
 
 		w.depth--
 		var (
@@ -646,7 +1117,7 @@ func (w *recordEncoder) visit(p *Payload, arr arrow.Array) error {
 		if err != nil {
 			return fmt.Errorf("could not retrieve zero-based value offsets for array %T: %w", arr, err)
 		}
-		p.body = append(p.body, voffsets)
+		w.appendBody(p, voffsets)
 
 		w.depth--
 		var (
@@ -737,6 +1208,35 @@ func (w *recordEncoder) getZeroBasedValueOffsets(arr arrow.Array) (*memory.Buffe
 	return voffsets, nil
 }
 
+// getZeroBasedValueOffsets64 is the int64-offset counterpart of
+// getZeroBasedValueOffsets, used by the Large Binary/String variants.
+func (w *recordEncoder) getZeroBasedValueOffsets64(arr arrow.Array) (*memory.Buffer, error) {
+	data := arr.Data()
+	voffsets := data.Buffers()[1]
+	offsetBytesNeeded := arrow.Int64Traits.BytesRequired(data.Len() + 1)
+
+	if data.Offset() != 0 || offsetBytesNeeded < voffsets.Len() {
+		shiftedOffsets := memory.NewResizableBuffer(w.mem)
+		shiftedOffsets.Resize(offsetBytesNeeded)
+
+		dest := arrow.Int64Traits.CastFromBytes(shiftedOffsets.Bytes())
+		offsets := arrow.Int64Traits.CastFromBytes(voffsets.Bytes())[data.Offset() : data.Offset()+data.Len()+1]
+
+		startOffset := offsets[0]
+		for i, o := range offsets {
+			dest[i] = o - startOffset
+		}
+		voffsets = shiftedOffsets
+	} else {
+		voffsets.Retain()
+	}
+	if voffsets == nil || voffsets.Len() == 0 {
+		return nil, nil
+	}
+
+	return voffsets, nil
+}
+
 func (w *recordEncoder) Encode(p *Payload, rec arrow.Record) error {
 	if err := w.encode(p, rec); err != nil {
 		return err
@@ -745,7 +1245,7 @@ func (w *recordEncoder) Encode(p *Payload, rec arrow.Record) error {
 }
 
 func (w *recordEncoder) encodeMetadata(p *Payload, nrows int64) error {
-	p.meta = writeRecordMessage(w.mem, nrows, p.size, w.fields, w.meta, w.codec)
+	p.meta = writeRecordMessage(w.mem, nrows, p.size, w.fields, w.meta, w.batchCodec)
 	return nil
 }
 
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipc
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v9/arrow"
+	"github.com/apache/arrow/go/v9/arrow/array"
+	"github.com/apache/arrow/go/v9/arrow/memory"
+)
+
+// orderRecordingPayloadWriter records the message type of every WritePayload
+// call in the order they actually reach it. Record batch writes are
+// artificially slowed down, widening the window in which a synchronously
+// written dictionary batch could have overtaken a still-pipelined earlier
+// record batch.
+type orderRecordingPayloadWriter struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (o *orderRecordingPayloadWriter) Start() error { return nil }
+func (o *orderRecordingPayloadWriter) Close() error { return nil }
+func (o *orderRecordingPayloadWriter) WritePayload(p Payload) error {
+	if p.msg == MessageRecordBatch {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	switch p.msg {
+	case MessageSchema:
+		o.order = append(o.order, "schema")
+	case MessageDictionaryBatch:
+		o.order = append(o.order, "dict")
+	case MessageRecordBatch:
+		o.order = append(o.order, "record")
+	}
+	return nil
+}
+
+func dictStringRecord(t *testing.T, mem memory.Allocator, schema *arrow.Schema, value string) arrow.Record {
+	t.Helper()
+	bldr := array.NewDictionaryBuilder(mem, schema.Field(0).Type.(*arrow.DictionaryType))
+	defer bldr.Release()
+	sbldr, ok := bldr.(*array.BinaryDictionaryBuilder)
+	if !ok {
+		t.Fatalf("expected a string dictionary builder, got %T", bldr)
+	}
+	sbldr.AppendString(value)
+	arr := sbldr.NewArray()
+	defer arr.Release()
+	return array.NewRecord(schema, []arrow.Array{arr}, int64(arr.Len()))
+}
+
+// TestWriterDictionaryRecordOrder guards against dictionary batches
+// overtaking an earlier, still-pipelined record batch: Write is called
+// twice with a changing dictionary, and the payload writer must see
+// schema, dict, record, dict, record in exactly that order.
+func TestWriterDictionaryRecordOrder(t *testing.T) {
+	mem := memory.NewGoAllocator()
+	dictType := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int8, ValueType: arrow.BinaryTypes.String}
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f0", Type: dictType, Nullable: true}}, nil)
+
+	pw := &orderRecordingPayloadWriter{}
+	w := NewWriterWithPayloadWriter(pw, WithSchema(schema))
+
+	rec1 := dictStringRecord(t, mem, schema, "a")
+	defer rec1.Release()
+	if err := w.Write(rec1); err != nil {
+		t.Fatalf("Write(rec1): %v", err)
+	}
+
+	rec2 := dictStringRecord(t, mem, schema, "b")
+	defer rec2.Release()
+	if err := w.Write(rec2); err != nil {
+		t.Fatalf("Write(rec2): %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := strings.Join(pw.order, ",")
+	want := "schema,dict,record,dict,record"
+	if got != want {
+		t.Fatalf("payload write order = %q, want %q", got, want)
+	}
+}